@@ -0,0 +1,240 @@
+// Package shoppingcart models items, carts, and products for a grocery/deli
+// style storefront where quantities can be fractional (e.g. half a pound)
+// but only in fixed half-unit increments.
+package shoppingcart
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/goatbytes/consoul/shoppingcart/pricing"
+)
+
+// quantityGranularity is the smallest unit a quantity may be expressed in.
+const quantityGranularity = 0.5
+
+// Item represents a product item with a quantity. A zero-value Quantity is
+// invalid on its own; use ParseQuantity or set it explicitly before adding
+// the item to a cart.
+type Item struct {
+	Name     string
+	Price    float64
+	Quantity float64
+}
+
+// ParseQuantity parses s into a quantity, rejecting negative values, zero,
+// and anything that isn't a multiple of the fixed 0.5 granularity (e.g.
+// grocery or deli-style carts where you can buy half a pound but not a
+// third). Zero is rejected rather than treated as "none" so a parsed
+// Quantity is always a valid, positive amount to add to a cart.
+func ParseQuantity(s string) (float64, error) {
+	q, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid quantity %q: %w", s, err)
+	}
+	if math.IsNaN(q) || math.IsInf(q, 0) {
+		return 0, fmt.Errorf("quantity %q must be a finite number", s)
+	}
+	if q <= 0 {
+		return 0, fmt.Errorf("quantity %q must be positive", s)
+	}
+	remainder := math.Mod(q, quantityGranularity)
+	if math.Abs(remainder) > 1e-9 && math.Abs(remainder-quantityGranularity) > 1e-9 {
+		return 0, fmt.Errorf("quantity %q must be a multiple of %g", s, quantityGranularity)
+	}
+	return q, nil
+}
+
+// CalculateTotal calculates the total price of items, accounting for
+// quantity.
+func CalculateTotal(items []Item) float64 {
+	total := 0.0
+	for _, item := range items {
+		total += item.Price * item.Quantity
+	}
+	return total
+}
+
+// PortionAmount describes how much of a portion-based item was consumed.
+type PortionAmount int
+
+const (
+	// None indicates nothing was consumed, or that portion tracking is not
+	// in use.
+	None PortionAmount = iota
+	// Half indicates half a portion was consumed.
+	Half
+	// Full indicates a full portion was consumed.
+	Full
+)
+
+// Portion represents loyalty points earned for a full or half portion.
+// Points must be set to a positive value for portion tracking to apply; a
+// zero-value Portion is treated as "not tracked" and earns no credit.
+type Portion struct {
+	Points int
+	Amount PortionAmount
+}
+
+// Score returns the points actually earned, prorating for a half portion and
+// awarding nothing until at least half a portion has been consumed.
+func (p Portion) Score() float64 {
+	switch p.Amount {
+	case Full:
+		return float64(p.Points)
+	case Half:
+		return float64(p.Points) / 2
+	default:
+		return 0
+	}
+}
+
+// ShoppingCart represents a shopping cart.
+type ShoppingCart struct {
+	Items     []Item
+	discounts []pricing.Discount
+}
+
+// NewShoppingCartWithCapacity creates an empty cart whose backing slice is
+// preallocated to hold n items, avoiding repeated reallocations on bulk
+// imports (e.g. restoring a saved cart or loading a catalog).
+func NewShoppingCartWithCapacity(n int) *ShoppingCart {
+	return &ShoppingCart{Items: make([]Item, 0, n)}
+}
+
+// Reserve grows the cart's backing slice so it can hold at least n items
+// without further reallocation, leaving existing items untouched.
+func (sc *ShoppingCart) Reserve(n int) {
+	if cap(sc.Items) >= n {
+		return
+	}
+	items := make([]Item, len(sc.Items), n)
+	copy(items, sc.Items)
+	sc.Items = items
+}
+
+// Compact shrinks the cart's backing slice capacity down to its current
+// length, releasing memory held after large removals.
+func (sc *ShoppingCart) Compact() {
+	if len(sc.Items) == cap(sc.Items) {
+		return
+	}
+	items := make([]Item, len(sc.Items))
+	copy(items, sc.Items)
+	sc.Items = items
+}
+
+// growFor ensures the cart's backing slice has room for n additional items,
+// using the classic doubling strategy so amortized growth stays O(1) per
+// item instead of reallocating on every append.
+func (sc *ShoppingCart) growFor(n int) {
+	need := len(sc.Items) + n
+	if need <= cap(sc.Items) {
+		return
+	}
+	newCap := need
+	if doubled := 2 * len(sc.Items); doubled > newCap {
+		newCap = doubled
+	}
+	items := make([]Item, len(sc.Items), newCap)
+	copy(items, sc.Items)
+	sc.Items = items
+}
+
+// AddItem adds an item to the cart, merging into an existing entry with the
+// same name by summing quantities rather than appending a duplicate. The
+// incoming item's Price replaces the stored one, so a later catalog price
+// change is reflected immediately rather than silently kept stale.
+func (sc *ShoppingCart) AddItem(item Item) {
+	for i := range sc.Items {
+		if sc.Items[i].Name == item.Name {
+			sc.Items[i].Quantity += item.Quantity
+			sc.Items[i].Price = item.Price
+			return
+		}
+	}
+	sc.growFor(1)
+	sc.Items = append(sc.Items, item)
+}
+
+// AddItems adds multiple items to the cart in one call, preallocating the
+// backing slice up front so large batch imports don't reallocate on every
+// item the way repeated AddItem calls would.
+func (sc *ShoppingCart) AddItems(items ...Item) {
+	sc.growFor(len(items))
+	for _, item := range items {
+		sc.AddItem(item)
+	}
+}
+
+// GetSubtotal returns the cart total before any cart-level discounts.
+func (sc *ShoppingCart) GetSubtotal() float64 {
+	return CalculateTotal(sc.Items)
+}
+
+// ApplyCartDiscount stacks a discount onto the cart. Discounts apply in the
+// order they were added and never mutate Items, so GetTotal always
+// recomputes from the current Items. A discount cannot be removed from the
+// cart once it is added.
+func (sc *ShoppingCart) ApplyCartDiscount(d pricing.Discount) {
+	sc.discounts = append(sc.discounts, d)
+}
+
+// GetDiscountTotal returns how much the cart's stacked discounts save off
+// the subtotal.
+func (sc *ShoppingCart) GetDiscountTotal() float64 {
+	return sc.GetSubtotal() - sc.GetTotal()
+}
+
+// GetTotal returns the cart total after applying all stacked discounts.
+func (sc *ShoppingCart) GetTotal() float64 {
+	total := sc.GetSubtotal()
+	for _, d := range sc.discounts {
+		total = d.Apply(total)
+	}
+	return total
+}
+
+// Product represents a product.
+type Product struct {
+	Name      string
+	Price     float64
+	discounts []pricing.Discount
+}
+
+// Priced returns the product's current effective price after its stacked
+// discounts, without mutating Price.
+func (p *Product) Priced() float64 {
+	price := p.Price
+	for _, d := range p.discounts {
+		price = d.Apply(price)
+	}
+	return price
+}
+
+// ApplyDiscount stacks a discount onto the product without mutating Price,
+// so Priced can be recomputed after adding or clearing discounts. Use this
+// directly with a pricing.PercentageDiscount for a plain, portion-
+// independent percentage discount; see ApplyPortionDiscount for the
+// portion-scaled case.
+func (p *Product) ApplyDiscount(d pricing.Discount) {
+	p.discounts = append(p.discounts, d)
+}
+
+// ApplyPortionDiscount stacks a percentage discount scaled by a
+// partially-consumed portion's Score relative to its Points. When portion
+// is zero-value (portion tracking disabled), this applies no discount.
+func (p *Product) ApplyPortionDiscount(percentage float64, portion Portion) {
+	factor := 0.0
+	if portion.Points > 0 {
+		factor = portion.Score() / float64(portion.Points)
+	}
+	p.ApplyDiscount(pricing.PercentageDiscount{Percentage: percentage * factor})
+}
+
+// ClearDiscounts removes all discounts stacked on the product, restoring
+// Priced to the base Price.
+func (p *Product) ClearDiscounts() {
+	p.discounts = nil
+}
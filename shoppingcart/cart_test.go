@@ -0,0 +1,202 @@
+package shoppingcart
+
+import (
+	"testing"
+
+	"github.com/goatbytes/consoul/shoppingcart/pricing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"1", 1, false},
+		{"1.5", 1.5, false},
+		{"0.5", 0.5, false},
+		{"2", 2, false},
+		{"0", 0, true},
+		{"-1", 0, true},
+		{"1.3", 0, true},
+		{"nope", 0, true},
+	}
+	for _, c := range cases {
+		got, err := ParseQuantity(c.in)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("ParseQuantity(%q) = %v, want error", c.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseQuantity(%q) returned unexpected error: %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseQuantity(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestCalculateTotal(t *testing.T) {
+	items := []Item{
+		{Name: "Apples", Price: 2, Quantity: 1.5},
+		{Name: "Bread", Price: 3, Quantity: 2},
+	}
+	got := CalculateTotal(items)
+	want := 2*1.5 + 3*2
+	if got != want {
+		t.Errorf("CalculateTotal() = %v, want %v", got, want)
+	}
+}
+
+func TestShoppingCartAddItemMergesQuantity(t *testing.T) {
+	sc := &ShoppingCart{}
+	sc.AddItem(Item{Name: "Apples", Price: 2, Quantity: 1})
+	sc.AddItem(Item{Name: "Apples", Price: 2.5, Quantity: 0.5})
+
+	if len(sc.Items) != 1 {
+		t.Fatalf("len(sc.Items) = %d, want 1", len(sc.Items))
+	}
+	if got, want := sc.Items[0].Quantity, 1.5; got != want {
+		t.Errorf("merged Quantity = %v, want %v", got, want)
+	}
+	if got, want := sc.Items[0].Price, 2.5; got != want {
+		t.Errorf("merged Price = %v, want %v (latest price should win)", got, want)
+	}
+}
+
+func TestNewShoppingCartWithCapacity(t *testing.T) {
+	sc := NewShoppingCartWithCapacity(10)
+	if len(sc.Items) != 0 {
+		t.Errorf("len(sc.Items) = %d, want 0", len(sc.Items))
+	}
+	if cap(sc.Items) != 10 {
+		t.Errorf("cap(sc.Items) = %d, want 10", cap(sc.Items))
+	}
+}
+
+func TestReserve(t *testing.T) {
+	sc := &ShoppingCart{}
+	sc.AddItem(Item{Name: "Apples", Price: 2, Quantity: 1})
+	sc.Reserve(5)
+	if cap(sc.Items) < 5 {
+		t.Errorf("cap(sc.Items) = %d, want >= 5", cap(sc.Items))
+	}
+	if len(sc.Items) != 1 {
+		t.Errorf("Reserve must not change len, got %d", len(sc.Items))
+	}
+
+	// Reserving a smaller amount than the current capacity is a no-op.
+	before := cap(sc.Items)
+	sc.Reserve(1)
+	if cap(sc.Items) != before {
+		t.Errorf("cap(sc.Items) changed from %d to %d on a smaller Reserve", before, cap(sc.Items))
+	}
+}
+
+func TestCompact(t *testing.T) {
+	sc := NewShoppingCartWithCapacity(10)
+	sc.AddItem(Item{Name: "Apples", Price: 2, Quantity: 1})
+	sc.Compact()
+	if cap(sc.Items) != len(sc.Items) {
+		t.Errorf("cap(sc.Items) = %d, want %d (len)", cap(sc.Items), len(sc.Items))
+	}
+}
+
+func TestAddItems(t *testing.T) {
+	sc := &ShoppingCart{}
+	sc.AddItems(
+		Item{Name: "Apples", Price: 2, Quantity: 1},
+		Item{Name: "Bread", Price: 3, Quantity: 2},
+		Item{Name: "Apples", Price: 2, Quantity: 0.5},
+	)
+	if len(sc.Items) != 2 {
+		t.Fatalf("len(sc.Items) = %d, want 2", len(sc.Items))
+	}
+	if got, want := sc.GetTotal(), 2*1.5+3*2; got != want {
+		t.Errorf("GetTotal() = %v, want %v", got, want)
+	}
+}
+
+func TestPortionScore(t *testing.T) {
+	cases := []struct {
+		p    Portion
+		want float64
+	}{
+		{Portion{Points: 10, Amount: Full}, 10},
+		{Portion{Points: 10, Amount: Half}, 5},
+		{Portion{Points: 10, Amount: None}, 0},
+		{Portion{}, 0},
+	}
+	for _, c := range cases {
+		if got := c.p.Score(); got != c.want {
+			t.Errorf("Portion(%+v).Score() = %v, want %v", c.p, got, c.want)
+		}
+	}
+}
+
+func TestApplyPortionDiscountZeroPortionAppliesNoDiscount(t *testing.T) {
+	p := &Product{Name: "Cheese", Price: 100}
+	p.ApplyPortionDiscount(50, Portion{})
+	if got, want := p.Priced(), 100.0; got != want {
+		t.Errorf("Priced() = %v, want %v (zero-value Portion must not grant a discount)", got, want)
+	}
+	if p.Price != 100 {
+		t.Errorf("Price = %v, want 100 (ApplyPortionDiscount must not mutate Price)", p.Price)
+	}
+}
+
+func TestApplyPortionDiscountFullPortion(t *testing.T) {
+	p := &Product{Name: "Cheese", Price: 100}
+	p.ApplyPortionDiscount(50, Portion{Points: 10, Amount: Full})
+	if got, want := p.Priced(), 50.0; got != want {
+		t.Errorf("Priced() = %v, want %v", got, want)
+	}
+}
+
+func TestApplyPortionDiscountHalfPortion(t *testing.T) {
+	p := &Product{Name: "Cheese", Price: 100}
+	p.ApplyPortionDiscount(50, Portion{Points: 10, Amount: Half})
+	if got, want := p.Priced(), 75.0; got != want {
+		t.Errorf("Priced() = %v, want %v", got, want)
+	}
+}
+
+func TestApplyDiscountPlainPercentageIgnoresPortion(t *testing.T) {
+	// ApplyDiscount takes a pricing.Discount directly, so a plain
+	// percentage discount doesn't need a Portion at all and isn't gated
+	// by one the way ApplyPortionDiscount is.
+	p := &Product{Name: "Cheese", Price: 100}
+	p.ApplyDiscount(pricing.PercentageDiscount{Percentage: 50})
+	if got, want := p.Priced(), 50.0; got != want {
+		t.Errorf("Priced() = %v, want %v", got, want)
+	}
+}
+
+func TestClearDiscounts(t *testing.T) {
+	p := &Product{Name: "Cheese", Price: 100}
+	p.ApplyPortionDiscount(50, Portion{Points: 10, Amount: Full})
+	p.ClearDiscounts()
+	if got, want := p.Priced(), 100.0; got != want {
+		t.Errorf("Priced() = %v, want %v after ClearDiscounts", got, want)
+	}
+}
+
+func TestShoppingCartApplyCartDiscount(t *testing.T) {
+	sc := &ShoppingCart{}
+	sc.AddItem(Item{Name: "Apples", Price: 2, Quantity: 2})
+	sc.AddItem(Item{Name: "Bread", Price: 3, Quantity: 2})
+	sc.ApplyCartDiscount(pricing.PercentageDiscount{Percentage: 50})
+
+	if got, want := sc.GetSubtotal(), 10.0; got != want {
+		t.Errorf("GetSubtotal() = %v, want %v", got, want)
+	}
+	if got, want := sc.GetTotal(), 5.0; got != want {
+		t.Errorf("GetTotal() = %v, want %v", got, want)
+	}
+	if got, want := sc.GetDiscountTotal(), 5.0; got != want {
+		t.Errorf("GetDiscountTotal() = %v, want %v", got, want)
+	}
+}
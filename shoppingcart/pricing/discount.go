@@ -0,0 +1,88 @@
+// Package pricing provides composable, non-destructive pricing discounts.
+package pricing
+
+// Discount transforms a price, returning the price after the discount is
+// applied. Implementations must be pure: calling Apply must not mutate any
+// state, so a chain of discounts can be recomputed or reordered freely.
+type Discount interface {
+	Apply(price float64) float64
+}
+
+// PercentageDiscount reduces a price by a fixed percentage.
+type PercentageDiscount struct {
+	Percentage float64
+}
+
+// Apply returns price reduced by Percentage.
+func (d PercentageDiscount) Apply(price float64) float64 {
+	return price * (1 - d.Percentage/100)
+}
+
+// FixedAmountDiscount reduces a price by a flat amount, never going below
+// zero.
+type FixedAmountDiscount struct {
+	Amount float64
+}
+
+// Apply returns price reduced by Amount, floored at zero.
+func (d FixedAmountDiscount) Apply(price float64) float64 {
+	if price < d.Amount {
+		return 0
+	}
+	return price - d.Amount
+}
+
+// BuyNGetMFree prices Quantity units bought at unitPrice = price/Quantity so
+// that every group of N+M units only charges for N of them. price is the
+// pre-discount cost of all Quantity units.
+type BuyNGetMFree struct {
+	N, M     int
+	Quantity int
+}
+
+// Apply returns the total price after subtracting the free units earned.
+func (d BuyNGetMFree) Apply(price float64) float64 {
+	if d.N <= 0 || d.Quantity <= 0 {
+		return price
+	}
+	unitPrice := price / float64(d.Quantity)
+	groupSize := d.N + d.M
+	fullGroups := d.Quantity / groupSize
+	remainder := d.Quantity % groupSize
+	payableInRemainder := remainder
+	if payableInRemainder > d.N {
+		payableInRemainder = d.N
+	}
+	payableUnits := fullGroups*d.N + payableInRemainder
+	return unitPrice * float64(payableUnits)
+}
+
+// Tier is a single threshold in a TieredDiscount, e.g. "10% off over $100".
+type Tier struct {
+	Threshold  float64
+	Percentage float64
+}
+
+// TieredDiscount applies the percentage of the highest threshold the price
+// meets or exceeds. Tiers need not be pre-sorted.
+type TieredDiscount struct {
+	Tiers []Tier
+}
+
+// Apply returns price discounted by the percentage of the highest threshold
+// tier that price meets or exceeds.
+func (d TieredDiscount) Apply(price float64) float64 {
+	matched := false
+	var bestThreshold, bestPercentage float64
+	for _, tier := range d.Tiers {
+		if price < tier.Threshold {
+			continue
+		}
+		if !matched || tier.Threshold > bestThreshold {
+			bestThreshold = tier.Threshold
+			bestPercentage = tier.Percentage
+			matched = true
+		}
+	}
+	return price * (1 - bestPercentage/100)
+}
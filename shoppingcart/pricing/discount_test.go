@@ -0,0 +1,52 @@
+package pricing
+
+import "testing"
+
+func TestPercentageDiscount(t *testing.T) {
+	d := PercentageDiscount{Percentage: 25}
+	if got, want := d.Apply(100), 75.0; got != want {
+		t.Errorf("Apply(100) = %v, want %v", got, want)
+	}
+}
+
+func TestFixedAmountDiscount(t *testing.T) {
+	cases := []struct {
+		amount, price, want float64
+	}{
+		{10, 100, 90},
+		{150, 100, 0},
+	}
+	for _, c := range cases {
+		d := FixedAmountDiscount{Amount: c.amount}
+		if got := d.Apply(c.price); got != c.want {
+			t.Errorf("FixedAmountDiscount{%v}.Apply(%v) = %v, want %v", c.amount, c.price, got, c.want)
+		}
+	}
+}
+
+func TestBuyNGetMFree(t *testing.T) {
+	// Buy 2 get 1 free, 9 units at $10 each ($90 total): 3 groups of 3,
+	// paying for 2 per group = 6 units = $60.
+	d := BuyNGetMFree{N: 2, M: 1, Quantity: 9}
+	if got, want := d.Apply(90), 60.0; got != want {
+		t.Errorf("Apply(90) = %v, want %v", got, want)
+	}
+}
+
+func TestTieredDiscountPicksHighestThresholdMet(t *testing.T) {
+	// Tiers are intentionally out of order and non-monotonic in percentage
+	// to verify the threshold, not the percentage, determines the winner.
+	d := TieredDiscount{Tiers: []Tier{
+		{Threshold: 100, Percentage: 10},
+		{Threshold: 50, Percentage: 20},
+	}}
+	if got, want := d.Apply(150), 135.0; got != want {
+		t.Errorf("Apply(150) = %v, want %v", got, want)
+	}
+	if got, want := d.Apply(75), 60.0; got != want {
+		t.Errorf("Apply(75) = %v, want %v", got, want)
+	}
+	if got, want := d.Apply(10), 10.0; got != want {
+		t.Errorf("Apply(10) = %v, want %v (no tier met)", got, want)
+	}
+}